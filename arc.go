@@ -0,0 +1,213 @@
+package ecache
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// ghostList - a capped, key-only FIFO: ARC's B1/B2 remember which keys were recently evicted
+// (no values, just enough to recognize a repeat) so the adaptive policy can tell a "recency"
+// ghost hit from a "frequency" one
+type ghostList struct {
+	keys map[string]struct{}
+	fifo []string
+	cap  int
+}
+
+func createGhostList(cap int) *ghostList {
+	return &ghostList{keys: make(map[string]struct{}, cap), fifo: make([]string, 0, cap), cap: cap}
+}
+
+func (g *ghostList) has(k string) bool {
+	_, ok := g.keys[k]
+	return ok
+}
+
+func (g *ghostList) len() int {
+	return len(g.fifo)
+}
+
+// push remembers k as the newest ghost, evicting the oldest one if now over capacity
+func (g *ghostList) push(k string) {
+	if g.cap <= 0 || g.has(k) {
+		return
+	}
+	g.keys[k] = struct{}{}
+	g.fifo = append(g.fifo, k)
+	if len(g.fifo) > g.cap {
+		delete(g.keys, g.fifo[0])
+		g.fifo = g.fifo[1:]
+	}
+}
+
+// remove drops k from the ghost list, e.g. once it has been promoted back into T1/T2
+func (g *ghostList) remove(k string) {
+	if !g.has(k) {
+		return
+	}
+	delete(g.keys, k)
+	for i, x := range g.fifo {
+		if x == k {
+			g.fifo = append(g.fifo[:i], g.fifo[i+1:]...)
+			break
+		}
+	}
+}
+
+// arcMeta - per-shard ARC bookkeeping that the plain dlnk/hmap bucket can't express on its own:
+// the two ghost lists and the adaptive target size for T1. `t1n`/`t2n` track live counts
+// ourselves because `bucket.del` only soft-deletes (ts=0, sunk to the tail for later physical
+// reuse) rather than shrinking `hmap`, so `len(hmap)` alone would overcount.
+type arcMeta struct {
+	b1, b2 *ghostList
+	t1n, t2n int
+	p, c     int // adaptive target size for T1, and the combined capacity |T1|+|T2| must respect
+}
+
+// ARC - switch this cache to ARC (Adaptive Replacement Cache) mode, generalizing the two-level
+// `insts[idx][0]`/`[1]` scaffold used by LRU2 into Megiddo & Modha's adaptive policy:
+// `insts[idx][0]` becomes T1 (seen once, recency), `insts[idx][1]` becomes T2 (seen again, or
+// promoted straight in on a ghost hit, frequency), and each shard keeps ghost lists B1/B2 of
+// evicted keys (no values) so the target size for T1 adapts to whether the workload is more
+// recency- or frequency-biased. Call before any Put/Get; mutually exclusive with LRU2/WithTinyLFU.
+func (c *Cache) ARC() *Cache {
+	c.arc = make([]*arcMeta, len(c.insts))
+	for i := range c.arc {
+		capPerBkt := cap(c.insts[i][0].m)
+		c.insts[i][1] = create(uint16(capPerBkt))
+		c.arc[i] = &arcMeta{b1: createGhostList(capPerBkt), b2: createGhostList(capPerBkt), c: capPerBkt}
+	}
+	return c
+}
+
+// arcEvict enforces |T1|+|T2| <= c before a new key is inserted: evicts from T1 once it has
+// reached its adaptive target size p (or T2 is empty), otherwise from T2; the evicted key moves
+// into the corresponding ghost list so a future miss on it can adapt p. Uses liveVictim, not
+// victim, because the physical tail picked by a naive victim() can itself already be a tombstone
+// left behind by an earlier eviction that hasn't been physically reused yet -- del-ing that again
+// would no-op, and giving up there (instead of finding an entry actually worth evicting) let the
+// combined |T1|+|T2| grow past c every time it happened, since the caller inserts regardless.
+func (c *Cache) arcEvict(idx int32) {
+	t1, t2, m := c.insts[idx][0], c.insts[idx][1], c.arc[idx]
+	if m.t1n+m.t2n < m.c {
+		return
+	}
+	if m.t1n > 0 && m.t1n >= m.p {
+		if k, ok := t1.liveVictim(); ok {
+			t1.del(k)
+			m.t1n--
+			m.b1.push(k)
+		}
+	} else if k, ok := t2.liveVictim(); ok {
+		t2.del(k)
+		m.t2n--
+		m.b2.push(k)
+	}
+}
+
+// arcEnsureRoom forcibly reclaims one of t's own live entries, with the usual bookkeeping (live
+// count, ghost list), if t has no free slot left and the entry bucket.put would reuse is still
+// live (a tombstone there is reused as is, so nothing more is evicted). T1 and T2 are each physically sized to the
+// shard's full combined capacity `c`, so one tier can reach that size purely from insertions
+// arcEvict chose to direct at it, independent of arcEvict's own |T1|+|T2| <= c logical check
+// (which may have just evicted from the *other* tier instead). Without this, bucket.put would pick
+// its own physical victim the moment it's asked to insert while full -- possibly a still-live
+// entry -- without arc.go's counters or ghost lists ever finding out.
+func (c *Cache) arcEnsureRoom(idx int32, t1 bool) {
+	m := c.arc[idx]
+	t, gl, tn := c.insts[idx][0], m.b1, &m.t1n
+	if !t1 {
+		t, gl, tn = c.insts[idx][1], m.b2, &m.t2n
+	}
+	if !t.full() || t.m[t.evictable()-1].ts <= 0 {
+		return
+	}
+	if k, ok := t.liveVictim(); ok {
+		t.del(k)
+		*tn--
+		gl.push(k)
+	}
+}
+
+// arcPut implements ARC's miss-handling rules: a live hit in T1/T2 is a plain update, a ghost
+// hit adapts p and graduates straight into T2, and a genuine miss lands in T1
+func (c *Cache) arcPut(idx int32, key string, val Value) (*Value, int) {
+	t1, t2, m := c.insts[idx][0], c.insts[idx][1], c.arc[idx]
+
+	if nd := t1.nodeRef(key); nd != nil && nd.ts > 0 {
+		return t1.put(key, val, c.on)
+	}
+	if nd := t2.nodeRef(key); nd != nil && nd.ts > 0 {
+		return t2.put(key, val, c.on)
+	}
+
+	switch {
+	case m.b1.has(key): // recency ghost hit: T1 is too small, grow p
+		m.p = minInt(m.c, m.p+maxInt(1, m.b2.len()/maxInt(1, m.b1.len())))
+		m.b1.remove(key)
+		c.arcEvict(idx)
+		c.arcEnsureRoom(idx, false)
+		v, s := t2.put(key, val, c.on)
+		m.t2n++
+		return v, s
+	case m.b2.has(key): // frequency ghost hit: T2 is too small, shrink p
+		m.p = maxInt(0, m.p-maxInt(1, m.b1.len()/maxInt(1, m.b2.len())))
+		m.b2.remove(key)
+		c.arcEvict(idx)
+		c.arcEnsureRoom(idx, false)
+		v, s := t2.put(key, val, c.on)
+		m.t2n++
+		return v, s
+	default: // plain miss
+		c.arcEvict(idx)
+		c.arcEnsureRoom(idx, true)
+		v, s := t1.put(key, val, c.on)
+		m.t1n++
+		return v, s
+	}
+}
+
+// arcGet implements ARC's hit-promotion rule: a repeat access to something still in T1 graduates
+// it to T2 (it's no longer just "seen once"), a T2 hit just refreshes its position
+func (c *Cache) arcGet(idx int32, key string) (*Value, int) {
+	t1, t2, m := c.insts[idx][0], c.insts[idx][1], c.arc[idx]
+	live := func(ts int64) bool { return ts > 0 && !(c.expiration > 0 && now()-ts > int64(c.expiration)) }
+	if nd, s := t2.get(key); s > 0 && live(nd.ts) {
+		return &nd.v, 1
+	}
+	if nd := t1.nodeRef(key); nd != nil && live(nd.ts) {
+		v := nd.v
+		if _, s := t1.del(key); s > 0 {
+			m.t1n--
+		}
+		c.arcEvict(idx) // keep |T1|+|T2| <= c the same way arcPut does, in case counts ever drift
+		c.arcEnsureRoom(idx, false)
+		nv, _ := t2.put(key, v, c.on)
+		m.t2n++
+		return nv, 1
+	}
+	return nil, 0
+}
+
+// arcDel removes key from whichever of T1/T2 currently holds it
+func (c *Cache) arcDel(idx int32, key string) (*Value, int) {
+	t1, t2, m := c.insts[idx][0], c.insts[idx][1], c.arc[idx]
+	if nd, s := t1.del(key); s > 0 {
+		m.t1n--
+		return &nd.v, 1
+	}
+	if nd, s := t2.del(key); s > 0 {
+		m.t2n--
+		return &nd.v, 1
+	}
+	return nil, 0
+}
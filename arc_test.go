@@ -0,0 +1,133 @@
+package ecache
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+// TestARCKeepsFrequentlyAccessedKeysUnderChurn checks ARC's core promotion/protection guarantee
+// purely through the public API: a small set of keys that are repeatedly re-accessed (and so
+// graduate into, and stay refreshed in, T2) survive a much larger stream of once-only keys
+// churning through T1, while those once-only keys are evicted.
+func TestARCKeepsFrequentlyAccessedKeysUnderChurn(t *testing.T) {
+	c := NewLRUCache(0, 8).ARC() // bucketCnt=0 resolves to a single shard, combined T1+T2 cap 8
+	hot := []string{"h1", "h2", "h3"}
+	for _, k := range hot {
+		c.Put(k, k)
+	}
+	for i := 0; i < 200; i++ {
+		for _, k := range hot {
+			c.Get(k) // keep the hot keys alive and refreshed in T2
+		}
+		c.Put("cold-"+strconv.Itoa(i), i) // a long stream of keys touched exactly once
+	}
+
+	for _, k := range hot {
+		if _, ok := c.Get(k); !ok {
+			t.Fatalf("hot key %q was evicted under churn, want it retained via ARC's T2 protection", k)
+		}
+	}
+	if _, ok := c.Get("cold-0"); ok {
+		t.Fatal("cold-0, a once-touched churn key, should have been evicted long ago")
+	}
+}
+
+// TestARCGhostHitReclaimsRecentlyEvictedKey checks ARC's adaptive rule purely through the public
+// API: re-Put-ing a key that was recently evicted from T1, while it is still remembered in the B1
+// ghost list, restores it with the new value instead of treating it as an ordinary cold miss.
+func TestARCGhostHitReclaimsRecentlyEvictedKey(t *testing.T) {
+	c := NewLRUCache(0, 4).ARC() // single shard, combined T1+T2 cap 4
+	for i := 0; i < 4; i++ {
+		c.Put("k"+strconv.Itoa(i), i) // fill T1 to capacity
+	}
+	c.Put("k4", 4) // over capacity: evicts the oldest entry, k0, into the B1 ghost list
+
+	if _, ok := c.Get("k0"); ok {
+		t.Fatal("k0 should have been evicted to make room for k4")
+	}
+
+	c.Put("k0", 99) // ghost hit: k0 is still remembered in B1
+
+	if v, ok := c.Get("k0"); !ok || v != 99 {
+		t.Fatalf("Get(k0) = %v, %v, want 99, true after ghost-hit re-admission", v, ok)
+	}
+}
+
+// TestARCLiveCountsMatchRealWalk is a white-box check (needs arc.go's unexported fields) that
+// m.t1n/m.t2n, the live-item counters arcEvict/arcGet rely on to know when |T1|+|T2| has reached
+// capacity, never drift from a true walk-and-count of T1/T2: under churn, bucket.del on an
+// already-soft-deleted tail is a no-op, and a decrement not gated on that used to undercount,
+// silently turning off ARC-aware eviction in favor of bucket.put's plain LRU-tail fallback.
+func TestARCLiveCountsMatchRealWalk(t *testing.T) {
+	c := NewLRUCache(0, 4).ARC() // single shard, combined T1+T2 cap 4
+	t1, t2, m := c.insts[0][0], c.insts[0][1], c.arc[0]
+
+	liveCount := func(b *cache) int {
+		n := 0
+		b.walk(func(_ string, _ *Value, ts int64) bool {
+			if ts > 0 {
+				n++
+			}
+			return true
+		})
+		return n
+	}
+
+	for i := 0; i < 200; i++ {
+		k := "k" + strconv.Itoa(i%6) // small key space to force heavy T1/T2 churn and ghost hits
+		if i%3 == 0 {
+			c.Get(k)
+		} else {
+			c.Put(k, i)
+		}
+
+		if real1, real2 := liveCount(t1), liveCount(t2); m.t1n != real1 || m.t2n != real2 {
+			t.Fatalf("iteration %d: m.t1n=%d, m.t2n=%d, want real t1=%d, t2=%d", i, m.t1n, m.t2n, real1, real2)
+		}
+	}
+}
+
+// TestARCLiveCountsMatchRealWalkUnderRandomChurn is the same white-box invariant as
+// TestARCLiveCountsMatchRealWalk, but driven by a wider, randomized key space so a tier's
+// *physical* array can fill to capacity on its own (independent of arcEvict's combined |T1|+|T2|
+// budget, since each tier is allocated at the shard's full combined capacity): arcEvict can
+// legitimately choose to evict from T2 while a plain miss inserts into T1, so if T1 reaches its own
+// physical capacity first, bucket.put's internal full-branch would otherwise reclaim T1's tail
+// itself -- live or not -- without m.t1n/m.t2n or the ghost lists ever being told.
+func TestARCLiveCountsMatchRealWalkUnderRandomChurn(t *testing.T) {
+	c := NewLRUCache(0, 6).ARC() // single shard, combined T1+T2 cap 6
+	t1, t2, m := c.insts[0][0], c.insts[0][1], c.arc[0]
+
+	liveCount := func(b *cache) int {
+		n := 0
+		b.walk(func(_ string, _ *Value, ts int64) bool {
+			if ts > 0 {
+				n++
+			}
+			return true
+		})
+		return n
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		k := "k" + strconv.Itoa(rng.Intn(30))
+		if rng.Intn(2) == 0 {
+			c.Get(k)
+		} else {
+			c.Put(k, i)
+		}
+
+		real1, real2 := liveCount(t1), liveCount(t2)
+		if real1 > cap(t1.m) || real2 > cap(t2.m) {
+			t.Fatalf("iteration %d: real t1=%d, t2=%d exceed physical capacity %d", i, real1, real2, cap(t1.m))
+		}
+		if m.t1n != real1 || m.t2n != real2 {
+			t.Fatalf("iteration %d: m.t1n=%d, m.t2n=%d, want real t1=%d, t2=%d", i, m.t1n, m.t2n, real1, real2)
+		}
+		if m.t1n+m.t2n > m.c {
+			t.Fatalf("iteration %d: m.t1n+m.t2n=%d exceeds combined capacity %d", i, m.t1n+m.t2n, m.c)
+		}
+	}
+}
@@ -28,39 +28,43 @@ type Value struct {
 	B []byte       // bytes
 }
 
-type node struct {
-	k  string
-	v  Value
-	ts int64 // nano timestamp
+type gnode[K comparable, V any] struct {
+	k       K
+	v       V
+	ts      int64         // nano timestamp
+	loading chan struct{} // non-nil while a GetOrLoad/Prefetch loader is in flight for k; closed when it resolves
 }
 
-type cache struct {
-	dlnk [][2]uint16       // double link list, 0 for prev, 1 for next, the first node stands for [tail, head]
-	m    []node            // memory pre-allocated
-	hmap map[string]uint16 // key -> idx in []node
-	last uint16            // last element index when not full
+// bucket - single shard of a lru cache, generic over key/value so it can back both the
+// historical `cache` (K=string, V=Value) and the typed `LRU[K, V]` front-end
+type bucket[K comparable, V any] struct {
+	dlnk [][2]uint16   // double link list, 0 for prev, 1 for next, the first node stands for [tail, head]
+	m    []gnode[K, V] // memory pre-allocated
+	hmap map[K]uint16  // key -> idx in []gnode
+	last uint16        // last element index when not full
 }
 
-func create(cap uint16) *cache {
-	return &cache{make([][2]uint16, cap+1), make([]node, cap), make(map[string]uint16, cap), 0}
+func createBucket[K comparable, V any](cap uint16) *bucket[K, V] {
+	return &bucket[K, V]{make([][2]uint16, cap+1), make([]gnode[K, V], cap), make(map[K]uint16, cap), 0}
 }
 
 // put a cache item into lru cache, if added return 1, updated return 0
-func (c *cache) put(k string, i *interface{}, b []byte, on inspector) (*Value, int) {
+func (c *bucket[K, V]) put(k K, v V, on GInspector[K, V]) (*V, int) {
 	if x, ok := c.hmap[k]; ok {
-		c.m[x-1].v.I, c.m[x-1].v.B, c.m[x-1].ts = i, b, now()
+		c.m[x-1].v, c.m[x-1].ts = v, now()
 		c.ajust(x, p, n) // refresh to head
 		return &c.m[x-1].v, 0
 	}
 
 	if c.last == uint16(cap(c.m)) {
-		tail := &c.m[c.dlnk[0][p]-1]
+		victim := c.evictable() // skips entries pinned by an in-flight GetOrLoad/Prefetch
+		tail := &c.m[victim-1]
 		if (*tail).ts > 0 { // do not notify for mark delete ones
 			on(PUT, (*tail).k, &(*tail).v, -1)
 		}
 		delete(c.hmap, (*tail).k)
-		c.hmap[k], (*tail).k, (*tail).v.I, (*tail).v.B, (*tail).ts = c.dlnk[0][p], k, i, b, now() // reuse to reduce gc
-		c.ajust(c.dlnk[0][p], p, n)                                                               // refresh to head
+		c.hmap[k], (*tail).k, (*tail).v, (*tail).ts, (*tail).loading = victim, k, v, now(), nil // reuse to reduce gc
+		c.ajust(victim, p, n)                                                                   // refresh to head
 		return &(*tail).v, 1
 	}
 
@@ -70,12 +74,12 @@ func (c *cache) put(k string, i *interface{}, b []byte, on inspector) (*Value, i
 	} else {
 		c.dlnk[c.dlnk[0][n]][p] = c.last
 	}
-	c.m[c.last-1].k, c.m[c.last-1].v.I, c.m[c.last-1].v.B, c.m[c.last-1].ts, c.dlnk[c.last], c.hmap[k], c.dlnk[0][n] = k, i, b, now(), [2]uint16{0, c.dlnk[0][n]}, c.last, c.last
+	c.m[c.last-1].k, c.m[c.last-1].v, c.m[c.last-1].ts, c.dlnk[c.last], c.hmap[k], c.dlnk[0][n] = k, v, now(), [2]uint16{0, c.dlnk[0][n]}, c.last, c.last
 	return &c.m[c.last-1].v, 1
 }
 
 // get value of key from lru cache with result
-func (c *cache) get(k string) (*node, int) {
+func (c *bucket[K, V]) get(k K) (*gnode[K, V], int) {
 	if x, ok := c.hmap[k]; ok {
 		c.ajust(x, p, n) // refresh to head
 		return &c.m[x-1], 1
@@ -84,7 +88,7 @@ func (c *cache) get(k string) (*node, int) {
 }
 
 // delete item by key from lru cache
-func (c *cache) del(k string) (*node, int) {
+func (c *bucket[K, V]) del(k K) (*gnode[K, V], int) {
 	if x, ok := c.hmap[k]; ok && c.m[x-1].ts > 0 {
 		c.m[x-1].ts = 0  // mark as deleted
 		c.ajust(x, n, p) // sink to tail
@@ -93,8 +97,56 @@ func (c *cache) del(k string) (*node, int) {
 	return nil, 0
 }
 
+// has reports whether k is currently present in the bucket
+func (c *bucket[K, V]) has(k K) bool {
+	_, ok := c.hmap[k]
+	return ok
+}
+
+// full reports whether the bucket is at capacity, i.e. the next put of a new key would evict
+func (c *bucket[K, V]) full() bool {
+	return c.last == uint16(cap(c.m))
+}
+
+// victim returns the key of the current LRU tail, the eviction candidate when full
+func (c *bucket[K, V]) victim() K {
+	return c.m[c.dlnk[0][p]-1].k
+}
+
+// liveVictim walks backward from the tail for the first still-live entry (ts > 0), skipping over
+// tombstones a prior del already sank to the tail that haven't been physically reused yet; ok is
+// false if every entry is dead (or the bucket holds nothing), meaning there's nothing left to evict
+func (c *bucket[K, V]) liveVictim() (k K, ok bool) {
+	for idx := c.dlnk[0][p]; idx != 0; idx = c.dlnk[idx][p] {
+		if c.m[idx-1].ts > 0 {
+			return c.m[idx-1].k, true
+		}
+	}
+	return k, false
+}
+
+// nodeRef peeks at k's node without touching LRU order, or nil if k is absent
+func (c *bucket[K, V]) nodeRef(k K) *gnode[K, V] {
+	if x, ok := c.hmap[k]; ok {
+		return &c.m[x-1]
+	}
+	return nil
+}
+
+// evictable walks backward from the tail for the first entry not pinned by an in-flight
+// GetOrLoad/Prefetch, so a placeholder survives eviction pressure until it resolves; if every
+// entry happens to be pinned, falls back to the tail itself rather than overflow the bucket
+func (c *bucket[K, V]) evictable() uint16 {
+	for idx := c.dlnk[0][p]; idx != 0; idx = c.dlnk[idx][p] {
+		if c.m[idx-1].loading == nil {
+			return idx
+		}
+	}
+	return c.dlnk[0][p]
+}
+
 // calls f sequentially for each valid item in the lru cache
-func (c *cache) walk(walker func(k string, v *Value, ts int64) bool) {
+func (c *bucket[K, V]) walk(walker func(k K, v *V, ts int64) bool) {
 	for idx := c.dlnk[0][n]; idx != 0; idx = c.dlnk[idx][n] {
 		if c.m[idx-1].ts > 0 && !walker(c.m[idx-1].k, &c.m[idx-1].v, c.m[idx-1].ts) {
 			return
@@ -103,12 +155,32 @@ func (c *cache) walk(walker func(k string, v *Value, ts int64) bool) {
 }
 
 // when f=0, t=1, move to head, otherwise to tail
-func (c *cache) ajust(idx, f, t uint16) {
+func (c *bucket[K, V]) ajust(idx, f, t uint16) {
 	if c.dlnk[idx][f] != 0 { // f=0, t=1, not head node, otherwise not tail
 		c.dlnk[c.dlnk[idx][t]][f], c.dlnk[c.dlnk[idx][f]][t], c.dlnk[idx][f], c.dlnk[idx][t], c.dlnk[c.dlnk[0][t]][f], c.dlnk[0][t] = c.dlnk[idx][f], c.dlnk[idx][t], 0, c.dlnk[0][t], idx, idx
 	}
 }
 
+// GInspector - can be used to statistics cache hit/miss rate or other scenario like ringbuf queue;
+// generic variant of inspector, parameterized over the typed `LRU[K, V]` front-end
+//
+//	`action`:PUT, `status`: evicted=-1, updated=0, added=1
+//	`action`:GET, `status`: miss=0, hit=1
+//	`action`:DEL, `status`: miss=0, hit=1
+//	`action`:REJECT, `status`: always 0, a newcomer the TinyLFU admission filter turned away
+//	`value` only valid when `status` is not 0 or `action` is PUT
+type GInspector[K comparable, V any] func(action int, key K, value *V, status int)
+
+// node/cache - the original untyped lru shard, kept under its historical names for
+// backward compatibility; it is simply a bucket keyed by string and valued by Value
+type node = gnode[string, Value]
+type cache = bucket[string, Value]
+type inspector = GInspector[string, Value]
+
+func create(cap uint16) *cache {
+	return createBucket[string, Value](cap)
+}
+
 func hashBKRD(s string) (hash int32) {
 	for i := 0; i < len(s); i++ {
 		hash = hash*131 + int32(s[i])
@@ -133,6 +205,10 @@ type Cache struct {
 	expiration time.Duration
 	on         inspector
 	mask       int32
+	tlfu       []*tinyLFUFilter // per-shard TinyLFU admission filter, nil unless WithTinyLFU is used
+	arc        []*arcMeta       // per-shard ARC ghost lists/adaptive state, nil unless ARC is used
+	sieve      []*sieveBucket   // per-shard SIEVE buckets, nil unless SIEVE is used
+	lfu        []*lfuBucket     // per-shard LFU buckets, nil unless LFU is used
 }
 
 // NewLRUCache - create lru cache
@@ -141,7 +217,7 @@ type Cache struct {
 // optional `expiration` is item alive time (and we only use lazy eviction here), default `0` stands for permanent
 func NewLRUCache(bucketCnt, capPerBkt uint16, expiration ...time.Duration) *Cache {
 	mask := maskOfNextPowOf2(bucketCnt)
-	c := &Cache{make([]sync.Mutex, mask+1), make([][2]*cache, mask+1), 0, func(int, string, *Value, int) {}, int32(mask)}
+	c := &Cache{make([]sync.Mutex, mask+1), make([][2]*cache, mask+1), 0, func(int, string, *Value, int) {}, int32(mask), nil, nil, nil, nil}
 	for i := range c.insts {
 		c.insts[i][0] = create(capPerBkt)
 	}
@@ -160,11 +236,91 @@ func (c *Cache) LRU2(capPerBkt uint16) *Cache {
 	return c
 }
 
+// WithTinyLFU - enable a TinyLFU admission filter in front of the LRU: once a bucket is full, a
+// newcomer only evicts the LRU tail if it is estimated (via a count-min sketch, backed by a
+// doorkeeper bloom filter for first-touch keys) to be accessed more often than that victim,
+// which keeps a burst of one-off keys from flushing out a genuinely hot working set
+// `counters` is the expected working-set size per bucket (typically capPerBkt), used to size
+// the sketch/doorkeeper to roughly 10x that many distinct keys
+func (c *Cache) WithTinyLFU(counters int) *Cache {
+	c.tlfu = make([]*tinyLFUFilter, len(c.insts))
+	for i := range c.tlfu {
+		c.tlfu[i] = createTinyLFUFilter(uint32(counters))
+	}
+	return c
+}
+
+// SIEVE - switch this cache to the SIEVE eviction policy, a simpler and more scan-resistant
+// alternative to LRU/LRU-2 for read-heavy workloads: `Get` only flips a `visited` bit instead of
+// reordering the list, and eviction is driven by a moving hand cursor instead of always reclaiming
+// the tail. Call before any Put/Get; mutually exclusive with LRU2/WithTinyLFU/LFU/ARC.
+func (c *Cache) SIEVE() *Cache {
+	c.sieve = make([]*sieveBucket, len(c.insts))
+	for i := range c.sieve {
+		c.sieve[i] = createSieveBucket(uint16(cap(c.insts[i][0].m)))
+	}
+	return c
+}
+
+// LFU - switch this cache to the LFU eviction policy: each item gains a frequency counter bumped
+// on every `Get`, and eviction always reclaims the lowest-frequency entry in O(1) via a
+// doubly-linked list of frequency buckets. Call before any Put/Get; mutually exclusive with
+// LRU2/WithTinyLFU/SIEVE/ARC. Chain with `Aging` to stop stale-but-once-hot entries from
+// dominating eviction decisions forever.
+func (c *Cache) LFU() *Cache {
+	c.lfu = make([]*lfuBucket, len(c.insts))
+	for i := range c.lfu {
+		c.lfu[i] = createLFUBucket(uint16(cap(c.insts[i][0].m)))
+	}
+	return c
+}
+
+// Aging - enable exponential aging for LFU mode, right-shifting every entry's frequency every
+// `every` puts, so stale-but-once-hot entries stop dominating eviction decisions forever
+func (c *Cache) Aging(every uint32) *Cache {
+	for i := range c.lfu {
+		c.lfu[i].agingEvery = every
+	}
+	return c
+}
+
 // put - put a item into cache
 func (c *Cache) put(key string, i *interface{}, b []byte) {
 	idx := hashBKRD(key) & c.mask
 	c.locks[idx].Lock()
-	v, status := c.insts[idx][0].put(key, i, b, c.on)
+	if c.arc != nil {
+		v, status := c.arcPut(idx, key, Value{i, b})
+		c.on(PUT, key, v, status)
+		c.locks[idx].Unlock()
+		return
+	}
+	if c.sieve != nil {
+		v, status := c.sieve[idx].put(key, i, b, c.on)
+		c.on(PUT, key, v, status)
+		c.locks[idx].Unlock()
+		return
+	}
+	if c.lfu != nil {
+		v, status := c.lfu[idx].put(key, i, b, c.on)
+		c.on(PUT, key, v, status)
+		c.locks[idx].Unlock()
+		return
+	}
+	if c.tlfu != nil {
+		// admit on the state as of before this call's own touch, so the doorkeeper's "have we seen
+		// this candidate in the current sample window yet" check is about prior accesses, not this one
+		reject := !c.insts[idx][0].has(key) && c.insts[idx][0].full() && !c.tlfu[idx].admit(key, c.insts[idx][0].victim())
+		// touch the candidate itself too: otherwise a key written via Put/PutBytes/PutInt64 without
+		// ever being Get-missed first stays stuck at a frequency estimate of 0 and can never outscore
+		// a resident victim, permanently wedging bulk/write-only population paths
+		c.tlfu[idx].touch(key)
+		if reject {
+			c.locks[idx].Unlock()
+			c.on(REJECT, key, nil, 0)
+			return
+		}
+	}
+	v, status := c.insts[idx][0].put(key, Value{i, b}, c.on)
 	c.on(PUT, key, v, status)
 	c.locks[idx].Unlock()
 }
@@ -214,6 +370,167 @@ func (c *Cache) GetInt64(key string) (int64, bool) {
 	return 0, false
 }
 
+// startLoadCheck inspects a possibly-nil existing node's loading/ts state; `done` tells the
+// caller whether that alone settles the call (and if so, what to return), letting startLoad share
+// this logic across whichever backend (ARC/SIEVE/LFU/plain) is actually active for the shard.
+func (c *Cache) startLoadCheck(loading chan struct{}, ts int64) (ch chan struct{}, shouldLoad, done bool) {
+	switch {
+	case loading != nil:
+		return loading, false, true // someone else is already loading it
+	case ts > 0 && !(c.expiration > 0 && now()-ts > int64(c.expiration)):
+		return nil, false, true // already cached and live
+	}
+	return nil, false, false
+}
+
+// startLoad checks key's current state and, on a genuine miss, installs a pinned placeholder
+// holding a fresh wait channel; `shouldLoad` tells the caller whether it won the right (and the
+// obligation) to run the loader. `ch` is nil when the value is already cached and live. Routes to
+// whichever backend (ARC/SIEVE/LFU/plain, mirroring Cache.get/put/Del) is actually active for the
+// shard, so the placeholder lands where later Gets will actually look for it.
+func (c *Cache) startLoad(key string) (ch chan struct{}, shouldLoad bool) {
+	idx := hashBKRD(key) & c.mask
+	c.locks[idx].Lock()
+	defer c.locks[idx].Unlock()
+
+	switch {
+	case c.arc != nil:
+		t1, t2, m := c.insts[idx][0], c.insts[idx][1], c.arc[idx]
+		nd := t2.nodeRef(key)
+		if nd == nil || nd.ts <= 0 { // a T2 tombstone must not hide a live or loading T1 entry
+			nd = t1.nodeRef(key)
+		}
+		if nd != nil {
+			if ch, shouldLoad, done := c.startLoadCheck(nd.loading, nd.ts); done {
+				return ch, shouldLoad
+			}
+		}
+		ch = make(chan struct{})
+		c.arcEvict(idx) // a placeholder lands in T1 exactly like a plain miss in arcPut
+		c.arcEnsureRoom(idx, true)
+		t1.put(key, Value{}, c.on)
+		nd = t1.nodeRef(key)
+		nd.ts, nd.loading = 0, ch
+		m.t1n++
+	case c.sieve != nil:
+		if nd := c.sieve[idx].nodeRef(key); nd != nil {
+			if ch, shouldLoad, done := c.startLoadCheck(nd.loading, nd.ts); done {
+				return ch, shouldLoad
+			}
+		}
+		ch = make(chan struct{})
+		c.sieve[idx].put(key, nil, nil, c.on)
+		nd := c.sieve[idx].nodeRef(key)
+		nd.ts, nd.loading = 0, ch // ts=0 makes it read back as a miss to any plain Get until resolved
+	case c.lfu != nil:
+		if nd := c.lfu[idx].nodeRef(key); nd != nil {
+			if ch, shouldLoad, done := c.startLoadCheck(nd.loading, nd.ts); done {
+				return ch, shouldLoad
+			}
+		}
+		ch = make(chan struct{})
+		c.lfu[idx].put(key, nil, nil, c.on)
+		nd := c.lfu[idx].nodeRef(key)
+		nd.ts, nd.loading = 0, ch
+	default:
+		if nd := c.insts[idx][0].nodeRef(key); nd != nil {
+			if ch, shouldLoad, done := c.startLoadCheck(nd.loading, nd.ts); done {
+				return ch, shouldLoad
+			}
+		}
+		ch = make(chan struct{})
+		c.insts[idx][0].put(key, Value{}, c.on)
+		nd := c.insts[idx][0].nodeRef(key)
+		nd.ts, nd.loading = 0, ch
+	}
+	return ch, true
+}
+
+// resolveLoad fills in (or, on error, unpins) the placeholder started by startLoad and wakes
+// everyone blocked on ch; routes to the same backend startLoad installed the placeholder in.
+func (c *Cache) resolveLoad(key string, ch chan struct{}, val interface{}, err error) (interface{}, error) {
+	idx := hashBKRD(key) & c.mask
+	c.locks[idx].Lock()
+	switch {
+	case c.arc != nil:
+		t1, t2 := c.insts[idx][0], c.insts[idx][1]
+		nd := t1.nodeRef(key) // startLoad always installs an ARC placeholder in T1
+		if nd == nil || nd.loading != ch {
+			nd = t2.nodeRef(key)
+		}
+		if nd != nil && nd.loading == ch {
+			if err != nil {
+				nd.loading = nil
+			} else {
+				nd.v, nd.ts, nd.loading = Value{&val, nil}, now(), nil
+				c.on(PUT, key, &nd.v, 1)
+			}
+		}
+	case c.sieve != nil:
+		if nd := c.sieve[idx].nodeRef(key); nd != nil && nd.loading == ch {
+			if err != nil {
+				nd.loading = nil // unpin; ts is already 0 so it still reads back as a miss
+			} else {
+				nd.v, nd.ts, nd.loading = Value{&val, nil}, now(), nil
+				c.on(PUT, key, &nd.v, 1)
+			}
+		}
+	case c.lfu != nil:
+		if nd := c.lfu[idx].nodeRef(key); nd != nil && nd.loading == ch {
+			if err != nil {
+				nd.loading = nil
+			} else {
+				nd.v, nd.ts, nd.loading = Value{&val, nil}, now(), nil
+				c.on(PUT, key, &nd.v, 1)
+			}
+		}
+	default:
+		if nd := c.insts[idx][0].nodeRef(key); nd != nil && nd.loading == ch {
+			if err != nil {
+				nd.loading = nil
+			} else {
+				nd.v, nd.ts, nd.loading = Value{&val, nil}, now(), nil
+				c.on(PUT, key, &nd.v, 1)
+			}
+		}
+	}
+	c.locks[idx].Unlock()
+	close(ch)
+	return val, err
+}
+
+// GetOrLoad - get value of key from cache, invoking loader inline on a miss and caching its
+// result; concurrent Get/GetOrLoad calls for the same key block on the same in-flight load, and
+// Prefetch calls return at once, instead of re-invoking loader (single-flight), following the NEO/ZODB client cache's
+// prefetch design
+func (c *Cache) GetOrLoad(key string, loader func(key string) (interface{}, error)) (interface{}, error) {
+	ch, shouldLoad := c.startLoad(key)
+	if !shouldLoad {
+		if ch == nil {
+			i, _ := c.Get(key)
+			return i, nil
+		}
+		<-ch
+		return c.GetOrLoad(key, loader) // loader that was in flight has resolved (or failed), re-check
+	}
+	val, err := loader(key)
+	return c.resolveLoad(key, ch, val, err)
+}
+
+// Prefetch - like GetOrLoad, but the loader runs in its own goroutine and Prefetch returns
+// immediately; useful to warm a key that will be needed soon without blocking the caller. A no-op
+// if key is already cached or already being (pre)loaded.
+func (c *Cache) Prefetch(key string, loader func(key string) (interface{}, error)) {
+	ch, shouldLoad := c.startLoad(key)
+	if !shouldLoad {
+		return
+	}
+	go func() {
+		val, err := loader(key)
+		c.resolveLoad(key, ch, val, err)
+	}()
+}
+
 func (c *Cache) _get(key string, idx, level int32) (*node, int) {
 	if n, s := c.insts[idx][level].get(key); s > 0 && !((c.expiration > 0 && now()-n.ts > int64(c.expiration)) || n.ts <= 0) {
 		return n, s // no necessary to remove the expired item here, otherwise will cause GC thrashing
@@ -224,6 +541,71 @@ func (c *Cache) _get(key string, idx, level int32) (*node, int) {
 func (c *Cache) get(key string) (i *interface{}, b []byte, _ bool) {
 	idx := hashBKRD(key) & c.mask
 	c.locks[idx].Lock()
+	if c.tlfu != nil {
+		c.tlfu[idx].touch(key) // record the access regardless of hit/miss, admission needs full history
+	}
+	var loading chan struct{}
+	switch {
+	case c.arc != nil:
+		if nd := c.insts[idx][1].nodeRef(key); nd != nil && nd.ts > 0 {
+			loading = nd.loading
+		} else if nd := c.insts[idx][0].nodeRef(key); nd != nil {
+			loading = nd.loading
+		}
+	case c.sieve != nil:
+		if nd := c.sieve[idx].nodeRef(key); nd != nil {
+			loading = nd.loading
+		}
+	case c.lfu != nil:
+		if nd := c.lfu[idx].nodeRef(key); nd != nil {
+			loading = nd.loading
+		}
+	default:
+		if nd := c.insts[idx][0].nodeRef(key); nd != nil {
+			loading = nd.loading
+		}
+	}
+	if loading != nil {
+		c.locks[idx].Unlock()
+		<-loading // a GetOrLoad/Prefetch is in flight for key, wait for it instead of reporting a miss
+		return c.get(key)
+	}
+	if c.arc != nil {
+		v, s := c.arcGet(idx, key)
+		if s <= 0 {
+			c.locks[idx].Unlock()
+			c.on(GET, key, nil, 0)
+			return
+		}
+		c.on(GET, key, v, 1)
+		i, b = v.I, v.B
+		c.locks[idx].Unlock()
+		return i, b, true
+	}
+	if c.sieve != nil {
+		nd, s := c.sieve[idx].get(key)
+		if s <= 0 || (c.expiration > 0 && now()-nd.ts > int64(c.expiration)) || nd.ts <= 0 {
+			c.locks[idx].Unlock()
+			c.on(GET, key, nil, 0)
+			return
+		}
+		c.on(GET, key, &nd.v, 1)
+		i, b = nd.v.I, nd.v.B
+		c.locks[idx].Unlock()
+		return i, b, true
+	}
+	if c.lfu != nil {
+		nd, s := c.lfu[idx].get(key)
+		if s <= 0 || (c.expiration > 0 && now()-nd.ts > int64(c.expiration)) || nd.ts <= 0 {
+			c.locks[idx].Unlock()
+			c.on(GET, key, nil, 0)
+			return
+		}
+		c.on(GET, key, &nd.v, 1)
+		i, b = nd.v.I, nd.v.B
+		c.locks[idx].Unlock()
+		return i, b, true
+	}
 	n, s := (*node)(nil), 0
 	if c.insts[idx][1] == nil { // (if LRU-2 mode not support, loss is little)
 		n, s = c._get(key, idx, 0) // normal lru mode
@@ -231,7 +613,7 @@ func (c *Cache) get(key string) (i *interface{}, b []byte, _ bool) {
 		if n, s = c.insts[idx][0].del(key); s <= 0 {
 			n, s = c._get(key, idx, 1) // re-find in level-1
 		} else {
-			c.insts[idx][1].put(key, n.v.I, n.v.B, c.on) // find in level-0, move to level-1
+			c.insts[idx][1].put(key, n.v, c.on) // find in level-0, move to level-1
 		}
 	}
 	if s <= 0 {
@@ -249,6 +631,39 @@ func (c *Cache) get(key string) (i *interface{}, b []byte, _ bool) {
 func (c *Cache) Del(key string) {
 	idx := hashBKRD(key) & c.mask
 	c.locks[idx].Lock()
+	if c.arc != nil {
+		v, s := c.arcDel(idx, key)
+		if s > 0 {
+			c.on(DEL, key, v, 1)
+			v.I, v.B = nil, nil // release now
+		} else {
+			c.on(DEL, key, nil, 0)
+		}
+		c.locks[idx].Unlock()
+		return
+	}
+	if c.sieve != nil {
+		nd, s := c.sieve[idx].del(key)
+		if s > 0 {
+			c.on(DEL, key, &nd.v, 1)
+			nd.v.I, nd.v.B = nil, nil // release now
+		} else {
+			c.on(DEL, key, nil, 0)
+		}
+		c.locks[idx].Unlock()
+		return
+	}
+	if c.lfu != nil {
+		nd, s := c.lfu[idx].del(key)
+		if s > 0 {
+			c.on(DEL, key, &nd.v, 1)
+			nd.v.I, nd.v.B = nil, nil // release now
+		} else {
+			c.on(DEL, key, nil, 0)
+		}
+		c.locks[idx].Unlock()
+		return
+	}
 	n, s := c.insts[idx][0].del(key)
 	if c.insts[idx][1] != nil { // (if LRU-2 mode not support, loss is little)
 		if n2, s2 := c.insts[idx][1].del(key); n2 != nil && (n == nil || n.ts < n2.ts) { // callback latest added one if both exists
@@ -268,8 +683,15 @@ func (c *Cache) Del(key string) {
 func (c *Cache) Walk(walker func(k string, v *Value, ts int64) bool) {
 	for i := range c.insts {
 		c.locks[i].Lock()
-		if c.insts[i][0].walk(walker); c.insts[i][1] != nil {
-			c.insts[i][1].walk(walker)
+		switch {
+		case c.sieve != nil:
+			c.sieve[i].walk(walker)
+		case c.lfu != nil:
+			c.lfu[i].walk(walker)
+		default:
+			if c.insts[i][0].walk(walker); c.insts[i][1] != nil {
+				c.insts[i][1].walk(walker)
+			}
 		}
 		c.locks[i].Unlock()
 	}
@@ -279,15 +701,9 @@ const (
 	PUT = iota + 1
 	GET
 	DEL
+	REJECT
 )
 
-// inspector - can be used to statistics cache hit/miss rate or other scenario like ringbuf queue
-//   `action`:PUT, `status`: evicted=-1, updated=0, added=1
-//   `action`:GET, `status`: miss=0, hit=1
-//   `action`:DEL, `status`: miss=0, hit=1
-//   `value` only valid when `status` is not 0 or `action` is PUT
-type inspector func(action int, key string, value *Value, status int)
-
 // Inspect - to inspect the actions
 func (c *Cache) Inspect(insptr inspector) {
 	old := c.on
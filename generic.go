@@ -0,0 +1,132 @@
+package ecache
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// hashKey - shard hash for a generic key, sharing hashBKRD for the common case (string) and
+// falling back to its formatted representation otherwise
+func hashKey[K comparable](k K) int32 {
+	if s, ok := any(k).(string); ok {
+		return hashBKRD(s)
+	}
+	return hashBKRD(fmt.Sprint(k))
+}
+
+// LRU - generic, typed concurrent cache structure; a front-end over the same bucket/dlnk/hmap
+// machinery as `Cache`, but stores `V` directly instead of boxing it into `interface{}`
+type LRU[K comparable, V any] struct {
+	locks      []sync.Mutex
+	insts      [][2]*bucket[K, V] // level-0 for normal LRU, level-1 for LRU-2
+	expiration time.Duration
+	on         GInspector[K, V]
+	mask       int32
+}
+
+// NewLRU - create a typed lru cache, e.g. `c := ecache.NewLRU[string, *Session](buckets, cap)`
+// `bucketCnt` is buckets that shard items to reduce lock racing
+// `capPerBkt` is length of each bucket, can store `capPerBkt * bucketCnt` count of items in LRU at most
+// optional `expiration` is item alive time (and we only use lazy eviction here), default `0` stands for permanent
+func NewLRU[K comparable, V any](bucketCnt, capPerBkt uint16, expiration ...time.Duration) *LRU[K, V] {
+	mask := maskOfNextPowOf2(bucketCnt)
+	c := &LRU[K, V]{make([]sync.Mutex, mask+1), make([][2]*bucket[K, V], mask+1), 0, func(int, K, *V, int) {}, int32(mask)}
+	for i := range c.insts {
+		c.insts[i][0] = createBucket[K, V](capPerBkt)
+	}
+	if len(expiration) > 0 {
+		c.expiration = expiration[0]
+	}
+	return c
+}
+
+// LRU2 - add LRU-2 support (especially LRU-2 that when item visited twice it moves to upper-level-cache)
+// `capPerBkt` is length of each LRU-2 bucket, can store extra `capPerBkt * bucketCnt` count of items in LRU at most
+func (c *LRU[K, V]) LRU2(capPerBkt uint16) *LRU[K, V] {
+	for i := range c.insts {
+		c.insts[i][1] = createBucket[K, V](capPerBkt)
+	}
+	return c
+}
+
+// Put - put an item into cache
+func (c *LRU[K, V]) Put(key K, val V) {
+	idx := hashKey(key) & c.mask
+	c.locks[idx].Lock()
+	v, status := c.insts[idx][0].put(key, val, c.on)
+	c.on(PUT, key, v, status)
+	c.locks[idx].Unlock()
+}
+
+func (c *LRU[K, V]) get(key K, idx, level int32) (*gnode[K, V], int) {
+	if nd, s := c.insts[idx][level].get(key); s > 0 && !((c.expiration > 0 && now()-nd.ts > int64(c.expiration)) || nd.ts <= 0) {
+		return nd, s // no necessary to remove the expired item here, otherwise will cause GC thrashing
+	}
+	return nil, 0
+}
+
+// Get - get value of key from cache with result
+func (c *LRU[K, V]) Get(key K) (v V, ok bool) {
+	idx := hashKey(key) & c.mask
+	c.locks[idx].Lock()
+	nd, s := (*gnode[K, V])(nil), 0
+	if c.insts[idx][1] == nil { // (if LRU-2 mode not support, loss is little)
+		nd, s = c.get(key, idx, 0) // normal lru mode
+	} else { // LRU-2 mode
+		if nd, s = c.insts[idx][0].del(key); s <= 0 {
+			nd, s = c.get(key, idx, 1) // re-find in level-1
+		} else {
+			c.insts[idx][1].put(key, nd.v, c.on) // find in level-0, move to level-1
+		}
+	}
+	if s <= 0 {
+		c.locks[idx].Unlock()
+		c.on(GET, key, nil, 0)
+		return
+	}
+	c.on(GET, key, &nd.v, 1)
+	v, ok = nd.v, true
+	c.locks[idx].Unlock()
+	return
+}
+
+// Del - delete item by key from cache
+func (c *LRU[K, V]) Del(key K) {
+	idx := hashKey(key) & c.mask
+	c.locks[idx].Lock()
+	nd, s := c.insts[idx][0].del(key)
+	if c.insts[idx][1] != nil { // (if LRU-2 mode not support, loss is little)
+		if nd2, s2 := c.insts[idx][1].del(key); nd2 != nil && (nd == nil || nd.ts < nd2.ts) { // callback latest added one if both exists
+			nd, s = nd2, s2
+		}
+	}
+	if s > 0 {
+		c.on(DEL, key, &nd.v, 1)
+		var zero V
+		nd.v = zero // release now
+	} else {
+		c.on(DEL, key, nil, 0)
+	}
+	c.locks[idx].Unlock()
+}
+
+// Walk - calls f sequentially for each valid item in the lru cache, return false to stop iteration for every bucket
+func (c *LRU[K, V]) Walk(walker func(k K, v *V, ts int64) bool) {
+	for i := range c.insts {
+		c.locks[i].Lock()
+		if c.insts[i][0].walk(walker); c.insts[i][1] != nil {
+			c.insts[i][1].walk(walker)
+		}
+		c.locks[i].Unlock()
+	}
+}
+
+// Inspect - to inspect the actions
+func (c *LRU[K, V]) Inspect(insptr GInspector[K, V]) {
+	old := c.on
+	c.on = func(action int, key K, value *V, status int) {
+		old(action, key, value, status) // call as the declared order, old first
+		insptr(action, key, value, status)
+	}
+}
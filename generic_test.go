@@ -0,0 +1,69 @@
+package ecache
+
+import "testing"
+
+// TestLRUGenericPutGetDel checks the typed front-end's basic Put/Get/Del round-trip, confirming
+// values come back with their original type (no interface{} boxing leaking through) and that Del
+// makes a key unreachable.
+func TestLRUGenericPutGetDel(t *testing.T) {
+	c := NewLRU[string, int](0, 2) // bucketCnt=0 resolves to a single shard, capacity 2
+	c.Put("a", 1)
+
+	v, ok := c.Get("a")
+	if !ok || v != 1 {
+		t.Fatalf("Get(a) = %v, %v, want 1, true", v, ok)
+	}
+
+	c.Del("a")
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("a should be unreachable after Del")
+	}
+}
+
+// TestLRUGenericEvictsOldest checks that once a shard is full, Put evicts the least-recently-used
+// entry, observed through Inspect rather than any unexported field.
+func TestLRUGenericEvictsOldest(t *testing.T) {
+	c := NewLRU[string, int](0, 2)
+	c.Put("a", 1)
+	c.Put("b", 2)
+
+	var evicted string
+	c.Inspect(func(action int, key string, value *int, status int) {
+		if action == PUT && status == -1 {
+			evicted = key
+		}
+	})
+	c.Put("c", 3) // bucket full: must evict a, the least-recently-used entry
+
+	if evicted != "a" {
+		t.Fatalf("evicted %q, want %q (least-recently-used entry)", evicted, "a")
+	}
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("a should have been evicted")
+	}
+	if _, ok := c.Get("b"); !ok {
+		t.Fatal("b should have survived eviction")
+	}
+}
+
+// TestLRUGenericLRU2Promotion checks that a second-level LRU-2 cache, once added, only promotes a
+// key to the upper tier on its second visit, mirroring Cache's own LRU-2 promotion rule.
+func TestLRUGenericLRU2Promotion(t *testing.T) {
+	c := NewLRU[string, int](0, 1).LRU2(1) // level-0 cap 1, level-1 (promoted) cap 1
+	c.Put("a", 1)
+	c.Put("b", 2) // over level-0 capacity: evicts a before it is ever promoted
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("a was never visited twice, should not have been promoted, and should be evicted")
+	}
+
+	c.Put("x", 10)
+	if _, ok := c.Get("x"); !ok { // second visit (after the Put) promotes x into the level-1 cache
+		t.Fatal("expected hit on x")
+	}
+	c.Put("y", 20) // over level-0 capacity again, but x is now safe in level-1
+
+	if v, ok := c.Get("x"); !ok || v != 10 {
+		t.Fatalf("Get(x) = %v, %v, want 10, true (x was promoted to LRU-2 and should survive)", v, ok)
+	}
+}
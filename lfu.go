@@ -0,0 +1,255 @@
+package ecache
+
+import "sort"
+
+type lfuNode struct {
+	k       string
+	v       Value
+	ts      int64         // nano timestamp
+	freq    uint32        // access frequency
+	loading chan struct{} // non-nil while a GetOrLoad/Prefetch loader is in flight for k; closed when it resolves
+}
+
+// freqNode - a node of the ascending, doubly-linked list of distinct frequencies; each one owns
+// a doubly-linked list (head/tail) of the items currently at that frequency
+type freqNode struct {
+	freq       uint32
+	head, tail uint16 // item-list within this freq, 1-based item idx, 0 for none
+	prev, next uint16 // freq-list links, 1-based freqNode idx, 0 for none
+}
+
+// lfuBucket - single shard of a LFU cache; keeps items bucketed by frequency so eviction (always
+// the head of the lowest-freq bucket) and frequency bumps (on Get) are both O(1)
+type lfuBucket struct {
+	m    []lfuNode         // memory pre-allocated
+	hmap map[string]uint16 // key -> idx in []lfuNode
+	last uint16            // high-water mark of allocated item slots
+
+	itemPrev, itemNext []uint16 // per-item links within its freqNode's item list
+	itemFN             []uint16 // per-item -> owning freqNode idx
+	itemFree           []uint16 // recycled item slots, from Del
+
+	fn     []freqNode // freqNode pool, 1-based, index 0 unused
+	fnFree []uint16   // recycled freqNode slots
+	fnLast uint16     // high-water mark of allocated freqNode slots
+	fnHead uint16     // lowest-freq freqNode, 0 when empty
+
+	puts       uint32 // put counter, used to trigger aging
+	agingEvery uint32 // right-shift every entry's freq every N puts, 0 disables aging
+}
+
+func createLFUBucket(cap uint16) *lfuBucket {
+	return &lfuBucket{
+		m:        make([]lfuNode, cap),
+		hmap:     make(map[string]uint16, cap),
+		itemPrev: make([]uint16, cap+1),
+		itemNext: make([]uint16, cap+1),
+		itemFN:   make([]uint16, cap+1),
+		fn:       make([]freqNode, cap+1),
+	}
+}
+
+func (c *lfuBucket) allocFreqNode() uint16 {
+	if l := len(c.fnFree); l > 0 {
+		idx := c.fnFree[l-1]
+		c.fnFree = c.fnFree[:l-1]
+		return idx
+	}
+	c.fnLast++
+	return c.fnLast
+}
+
+// spliceFreqNodeAfter allocates a fresh freqNode for `freq` and links it right after `prevFN`
+// (prevFN=0 means "new head")
+func (c *lfuBucket) spliceFreqNodeAfter(prevFN uint16, freq uint32) uint16 {
+	idx := c.allocFreqNode()
+	var nextFN uint16
+	if prevFN != 0 {
+		nextFN = c.fn[prevFN].next
+	} else {
+		nextFN = c.fnHead
+	}
+	c.fn[idx] = freqNode{freq: freq, prev: prevFN, next: nextFN}
+	if prevFN != 0 {
+		c.fn[prevFN].next = idx
+	} else {
+		c.fnHead = idx
+	}
+	if nextFN != 0 {
+		c.fn[nextFN].prev = idx
+	}
+	return idx
+}
+
+// ensureFreqNode finds (or creates, in sorted position) the freqNode for `freq`, scanning
+// forward from the freq-list head; distinct frequencies in use are few in practice, so this
+// stays effectively O(1) for the common increment-by-one case
+func (c *lfuBucket) ensureFreqNode(freq uint32) uint16 {
+	prev, cur := uint16(0), c.fnHead
+	for cur != 0 && c.fn[cur].freq < freq {
+		prev, cur = cur, c.fn[cur].next
+	}
+	if cur != 0 && c.fn[cur].freq == freq {
+		return cur
+	}
+	return c.spliceFreqNodeAfter(prev, freq)
+}
+
+// detachItem removes item idx from its current freqNode's item list, recycling that freqNode
+// if it becomes empty
+func (c *lfuBucket) detachItem(idx uint16) {
+	f := c.itemFN[idx]
+	pv, nx := c.itemPrev[idx], c.itemNext[idx]
+	if pv != 0 {
+		c.itemNext[pv] = nx
+	} else {
+		c.fn[f].head = nx
+	}
+	if nx != 0 {
+		c.itemPrev[nx] = pv
+	} else {
+		c.fn[f].tail = pv
+	}
+	if c.fn[f].head == 0 {
+		if c.fn[f].prev != 0 {
+			c.fn[c.fn[f].prev].next = c.fn[f].next
+		} else {
+			c.fnHead = c.fn[f].next
+		}
+		if c.fn[f].next != 0 {
+			c.fn[c.fn[f].next].prev = c.fn[f].prev
+		}
+		c.fnFree = append(c.fnFree, f)
+	}
+}
+
+// attachItem appends item idx to the tail of freqNode f's item list
+func (c *lfuBucket) attachItem(idx, f uint16) {
+	c.itemFN[idx] = f
+	c.itemPrev[idx], c.itemNext[idx] = c.fn[f].tail, 0
+	if c.fn[f].tail != 0 {
+		c.itemNext[c.fn[f].tail] = idx
+	} else {
+		c.fn[f].head = idx
+	}
+	c.fn[f].tail = idx
+}
+
+// age right-shifts every live entry's frequency and rebuilds the freq-node list accordingly
+func (c *lfuBucket) age() {
+	type ent struct {
+		idx  uint16
+		freq uint32
+	}
+	items := make([]ent, 0, len(c.hmap))
+	for f := c.fnHead; f != 0; f = c.fn[f].next {
+		for it := c.fn[f].head; it != 0; it = c.itemNext[it] {
+			nf := c.m[it-1].freq >> 1
+			c.m[it-1].freq = nf
+			items = append(items, ent{it, nf})
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].freq < items[j].freq })
+
+	c.fnHead, c.fnLast, c.fnFree = 0, 0, c.fnFree[:0]
+	c.fn = make([]freqNode, len(c.fn))
+
+	var lastFN uint16
+	var lastFreq uint32
+	for i, e := range items {
+		if i == 0 || e.freq != lastFreq {
+			lastFN, lastFreq = c.spliceFreqNodeAfter(lastFN, e.freq), e.freq
+		}
+		c.attachItem(e.idx, lastFN)
+	}
+}
+
+// evictable returns the item idx to reclaim when full: the oldest entry of the lowest-freq
+// bucket that isn't pinned by an in-flight GetOrLoad/Prefetch, scanning forward through
+// progressively higher-freq buckets if the lowest one is entirely pinned; falls back to the
+// lowest-freq bucket's own head rather than overflow the cache if every entry is pinned
+func (c *lfuBucket) evictable() uint16 {
+	for f := c.fnHead; f != 0; f = c.fn[f].next {
+		for it := c.fn[f].head; it != 0; it = c.itemNext[it] {
+			if c.m[it-1].loading == nil {
+				return it
+			}
+		}
+	}
+	return c.fn[c.fnHead].head
+}
+
+// put a cache item into the lfu cache, if added return 1, updated return 0
+func (c *lfuBucket) put(k string, i *interface{}, b []byte, on inspector) (*Value, int) {
+	if x, ok := c.hmap[k]; ok {
+		c.m[x-1].v.I, c.m[x-1].v.B, c.m[x-1].ts = i, b, now()
+		return &c.m[x-1].v, 0 // update does not bump frequency, only Get does
+	}
+
+	if c.puts++; c.agingEvery > 0 && c.puts%c.agingEvery == 0 {
+		c.age()
+	}
+
+	var x uint16
+	switch {
+	case len(c.hmap) == cap(c.m): // full, evict the oldest non-pinned entry in the lowest-freq bucket
+		x = c.evictable()
+		if c.m[x-1].ts > 0 { // do not notify for mark delete ones
+			on(PUT, c.m[x-1].k, &c.m[x-1].v, -1)
+		}
+		delete(c.hmap, c.m[x-1].k)
+		c.detachItem(x)
+	case len(c.itemFree) > 0:
+		l := len(c.itemFree)
+		x, c.itemFree = c.itemFree[l-1], c.itemFree[:l-1]
+	default:
+		c.last++
+		x = c.last
+	}
+
+	c.hmap[k], c.m[x-1].k, c.m[x-1].v.I, c.m[x-1].v.B, c.m[x-1].ts, c.m[x-1].freq, c.m[x-1].loading = x, k, i, b, now(), 1, nil
+	c.attachItem(x, c.ensureFreqNode(1))
+	return &c.m[x-1].v, 1
+}
+
+// get value of key from the lfu cache with result, bumping its frequency
+func (c *lfuBucket) get(k string) (*lfuNode, int) {
+	if x, ok := c.hmap[k]; ok {
+		c.detachItem(x)
+		c.m[x-1].freq++
+		c.attachItem(x, c.ensureFreqNode(c.m[x-1].freq))
+		return &c.m[x-1], 1
+	}
+	return nil, 0
+}
+
+// nodeRef peeks at k's node without bumping its frequency, or nil if k is absent
+func (c *lfuBucket) nodeRef(k string) *lfuNode {
+	if x, ok := c.hmap[k]; ok {
+		return &c.m[x-1]
+	}
+	return nil
+}
+
+// delete item by key from the lfu cache, freeing its slot for immediate reuse
+func (c *lfuBucket) del(k string) (*lfuNode, int) {
+	if x, ok := c.hmap[k]; ok {
+		delete(c.hmap, k)
+		c.detachItem(x)
+		c.m[x-1].ts = 0
+		c.itemFree = append(c.itemFree, x)
+		return &c.m[x-1], 1
+	}
+	return nil, 0
+}
+
+// calls f sequentially for each valid item in the lfu cache, lowest frequency first
+func (c *lfuBucket) walk(walker func(k string, v *Value, ts int64) bool) {
+	for f := c.fnHead; f != 0; f = c.fn[f].next {
+		for it := c.fn[f].head; it != 0; it = c.itemNext[it] {
+			if c.m[it-1].ts > 0 && !walker(c.m[it-1].k, &c.m[it-1].v, c.m[it-1].ts) {
+				return
+			}
+		}
+	}
+}
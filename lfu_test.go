@@ -0,0 +1,64 @@
+package ecache
+
+import "testing"
+
+// TestLFUEvictsLowestFrequency checks LFU's core eviction guarantee purely through the public
+// API: a bucket full entry that was never re-accessed (frequency 1) is reclaimed before entries
+// that were Get at least once since insertion, even though it is not the oldest by insertion order.
+func TestLFUEvictsLowestFrequency(t *testing.T) {
+	c := NewLRUCache(0, 3).LFU() // bucketCnt=0 resolves to a single shard, capacity 3
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Put("c", 3)
+	c.Get("a") // bump a and c to frequency 2, b stays at frequency 1
+	c.Get("c")
+
+	var evicted string
+	c.Inspect(func(action int, key string, value *Value, status int) {
+		if action == PUT && status == -1 {
+			evicted = key
+		}
+	})
+	c.Put("d", 4) // bucket full: must evict the lowest-frequency entry, b
+
+	if evicted != "b" {
+		t.Fatalf("evicted %q, want %q (lowest-frequency entry)", evicted, "b")
+	}
+	if _, ok := c.Get("a"); !ok {
+		t.Fatal("a (freq 2) should have survived eviction")
+	}
+	if _, ok := c.Get("c"); !ok {
+		t.Fatal("c (freq 2) should have survived eviction")
+	}
+	if _, ok := c.Get("b"); ok {
+		t.Fatal("b (freq 1) should have been evicted")
+	}
+}
+
+// TestLFUAgingDemotesStaleFrequency checks that Aging periodically right-shifts every entry's
+// frequency: a burst of past Gets keeps paying off for an entry across several aging rounds, while
+// an entry inserted once and never touched again decays to the bottom and is the one reclaimed.
+func TestLFUAgingDemotesStaleFrequency(t *testing.T) {
+	c := NewLRUCache(0, 2).LFU().Aging(1) // age on every put, single shard, capacity 2
+	c.Put("hot", 1)
+	for i := 0; i < 5; i++ {
+		c.Get("hot") // freq: 1 -> 6, well above anything a newcomer can reach
+	}
+
+	c.Put("filler", 2) // new key triggers aging: hot's freq 6 -> 3, filler itself inserted at freq 1
+
+	var evicted string
+	c.Inspect(func(action int, key string, value *Value, status int) {
+		if action == PUT && status == -1 {
+			evicted = key
+		}
+	})
+	c.Put("newcomer", 3) // new key triggers aging again: hot 3 -> 1, filler 1 -> 0; bucket full, evicts filler
+
+	if evicted == "" {
+		t.Fatal("expected an eviction once the bucket is full")
+	}
+	if evicted != "filler" {
+		t.Fatalf("evicted %q, want %q (lowest frequency after repeated aging)", evicted, "filler")
+	}
+}
@@ -0,0 +1,151 @@
+package ecache
+
+type sieveNode struct {
+	k       string
+	v       Value
+	ts      int64         // nano timestamp
+	visited uint8         // SIEVE visited bit, set on Get, cleared by the hand as it sweeps past
+	loading chan struct{} // non-nil while a GetOrLoad/Prefetch loader is in flight for k; closed when it resolves
+}
+
+// sieveBucket - single shard of a SIEVE cache; shares the dlnk/hmap layout with `bucket`, but
+// `get` never reorders the list (it only flips `visited`), and eviction is driven by a moving
+// `hand` cursor instead of always reclaiming the tail
+type sieveBucket struct {
+	dlnk [][2]uint16       // double link list, 0 for prev, 1 for next, the first node stands for [tail, head]
+	m    []sieveNode       // memory pre-allocated
+	hmap map[string]uint16 // key -> idx in []sieveNode
+	last uint16            // last element index when not full
+	hand uint16            // current sieve hand position, 0 stands for "not started yet"
+}
+
+func createSieveBucket(cap uint16) *sieveBucket {
+	return &sieveBucket{make([][2]uint16, cap+1), make([]sieveNode, cap), make(map[string]uint16, cap), 0, 0}
+}
+
+// unlink removes idx from the list without touching its own dlnk slot
+func (c *sieveBucket) unlink(idx uint16) {
+	pn, nn := c.dlnk[idx][p], c.dlnk[idx][n]
+	if pn != 0 {
+		c.dlnk[pn][n] = nn
+	} else {
+		c.dlnk[0][n] = nn // idx was head
+	}
+	if nn != 0 {
+		c.dlnk[nn][p] = pn
+	} else {
+		c.dlnk[0][p] = pn // idx was tail
+	}
+}
+
+// insertHead links idx in as the new head, SIEVE always inserts new entries at the head
+func (c *sieveBucket) insertHead(idx uint16) {
+	head := c.dlnk[0][n]
+	c.dlnk[idx] = [2]uint16{0, head}
+	if head != 0 {
+		c.dlnk[head][p] = idx
+	} else {
+		c.dlnk[0][p] = idx // list was empty, idx is head and tail
+	}
+	c.dlnk[0][n] = idx
+}
+
+// insertTail links idx in as the new tail, used to sink a soft-deleted entry for quick reclaim
+func (c *sieveBucket) insertTail(idx uint16) {
+	tail := c.dlnk[0][p]
+	c.dlnk[idx] = [2]uint16{tail, 0}
+	if tail != 0 {
+		c.dlnk[tail][n] = idx
+	} else {
+		c.dlnk[0][n] = idx // list was empty, idx is head and tail
+	}
+	c.dlnk[0][p] = idx
+}
+
+// evict walks the hand forward from the tail (towards the head, oldest to newest) clearing
+// visited bits until it finds an unvisited entry, evicts it and advances the hand to its
+// successor in the walk, wrapping from the head back to the tail; entries pinned by an in-flight
+// GetOrLoad/Prefetch are skipped (without clearing visited) so a placeholder survives eviction
+// pressure until it resolves, falling back to the hand itself if every entry happens to be pinned
+func (c *sieveBucket) evict(on inspector) uint16 {
+	h := c.hand
+	if h == 0 {
+		h = c.dlnk[0][p] // start at the tail on the very first eviction
+	}
+	for steps := 0; c.m[h-1].loading != nil || c.m[h-1].visited != 0; {
+		if c.m[h-1].loading == nil {
+			c.m[h-1].visited = 0
+		}
+		if steps++; steps >= 2*len(c.m) { // one lap clears every unpinned visited bit, a second finds it
+			break // every entry is pinned, fall back to evicting the hand itself
+		}
+		if h = c.dlnk[h][p]; h == 0 {
+			h = c.dlnk[0][p] // wrap from the head back to the tail
+		}
+	}
+	if c.m[h-1].ts > 0 { // do not notify for mark delete ones
+		on(PUT, c.m[h-1].k, &c.m[h-1].v, -1)
+	}
+	delete(c.hmap, c.m[h-1].k)
+	if c.hand = c.dlnk[h][p]; c.hand == 0 {
+		c.hand = c.dlnk[0][p]
+	}
+	c.unlink(h)
+	return h
+}
+
+// put a cache item into the sieve cache, if added return 1, updated return 0
+func (c *sieveBucket) put(k string, i *interface{}, b []byte, on inspector) (*Value, int) {
+	if x, ok := c.hmap[k]; ok {
+		c.m[x-1].v.I, c.m[x-1].v.B, c.m[x-1].ts = i, b, now()
+		return &c.m[x-1].v, 0 // SIEVE never reorders or marks visited on a plain put
+	}
+
+	x, status := uint16(0), 1
+	if c.last == uint16(cap(c.m)) {
+		x = c.evict(on)
+	} else {
+		c.last++
+		x = c.last
+	}
+	c.hmap[k], c.m[x-1].k, c.m[x-1].v.I, c.m[x-1].v.B, c.m[x-1].ts, c.m[x-1].visited, c.m[x-1].loading = x, k, i, b, now(), 0, nil
+	c.insertHead(x)
+	return &c.m[x-1].v, status
+}
+
+// get value of key from the sieve cache with result, setting the visited bit without reordering
+func (c *sieveBucket) get(k string) (*sieveNode, int) {
+	if x, ok := c.hmap[k]; ok {
+		c.m[x-1].visited = 1 // huge win for read-heavy workloads: no lock-holding list surgery
+		return &c.m[x-1], 1
+	}
+	return nil, 0
+}
+
+// nodeRef peeks at k's node without touching visited/hand state, or nil if k is absent
+func (c *sieveBucket) nodeRef(k string) *sieveNode {
+	if x, ok := c.hmap[k]; ok {
+		return &c.m[x-1]
+	}
+	return nil
+}
+
+// delete item by key from the sieve cache, sinking it to the tail so it is reclaimed first
+func (c *sieveBucket) del(k string) (*sieveNode, int) {
+	if x, ok := c.hmap[k]; ok && c.m[x-1].ts > 0 {
+		c.m[x-1].ts = 0 // mark as deleted
+		c.unlink(x)
+		c.insertTail(x) // sink to tail
+		return &c.m[x-1], 1
+	}
+	return nil, 0
+}
+
+// calls f sequentially for each valid item in the sieve cache
+func (c *sieveBucket) walk(walker func(k string, v *Value, ts int64) bool) {
+	for idx := c.dlnk[0][n]; idx != 0; idx = c.dlnk[idx][n] {
+		if c.m[idx-1].ts > 0 && !walker(c.m[idx-1].k, &c.m[idx-1].v, c.m[idx-1].ts) {
+			return
+		}
+	}
+}
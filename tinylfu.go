@@ -0,0 +1,170 @@
+package ecache
+
+// tinyLFUHashes derives 4 independent indices for the count-min sketch / doorkeeper from a
+// single BKRD hash, each row mixing in a different odd multiplier; cheap and good enough since
+// the sketch only needs to decorrelate, not resist adversarial input
+func tinyLFUHashes(k string, mask uint32) (h [4]uint32) {
+	base := uint32(hashBKRD(k))
+	seeds := [4]uint32{0x9E3779B1, 0x85EBCA77, 0xC2B2AE3D, 0x27D4EB2F}
+	for i, sd := range seeds {
+		x := base ^ sd
+		x ^= x >> 15
+		x *= sd
+		x ^= x >> 13
+		h[i] = x & mask
+	}
+	return h
+}
+
+// pow2Ceil rounds v up to the next power of 2, minimum 1
+func pow2Ceil(v uint32) uint32 {
+	if v < 2 {
+		return 1
+	}
+	v--
+	v |= v >> 1
+	v |= v >> 2
+	v |= v >> 4
+	v |= v >> 8
+	v |= v >> 16
+	return v + 1
+}
+
+// countMinSketch - a 4-row Count-Min Sketch of 4-bit counters (two packed per byte), used to
+// estimate how often a key has been touched without keeping a map of exact counts
+type countMinSketch struct {
+	rows [4][]byte // 4-bit counters, 2 per byte
+	mask uint32     // (counters per row) - 1, counters per row is a power of 2
+}
+
+func createCountMinSketch(counters uint32) *countMinSketch {
+	width := pow2Ceil(counters)
+	s := &countMinSketch{mask: width - 1}
+	for i := range s.rows {
+		s.rows[i] = make([]byte, (width+1)/2)
+	}
+	return s
+}
+
+func (s *countMinSketch) get(row int, idx uint32) uint8 {
+	b := s.rows[row][idx>>1]
+	if idx&1 == 0 {
+		return b & 0x0f
+	}
+	return b >> 4
+}
+
+func (s *countMinSketch) set(row int, idx uint32, v uint8) {
+	p := &s.rows[row][idx>>1]
+	if idx&1 == 0 {
+		*p = (*p &^ 0x0f) | (v & 0x0f)
+	} else {
+		*p = (*p &^ 0xf0) | (v << 4)
+	}
+}
+
+// add bumps the 4 counters for k, saturating at 15 (the max a 4-bit counter can hold)
+func (s *countMinSketch) add(k string) {
+	h := tinyLFUHashes(k, s.mask)
+	for row, idx := range h {
+		if v := s.get(row, idx); v < 15 {
+			s.set(row, idx, v+1)
+		}
+	}
+}
+
+// estimate returns the minimum of k's 4 counters, the Count-Min Sketch's frequency estimate
+func (s *countMinSketch) estimate(k string) uint8 {
+	h := tinyLFUHashes(k, s.mask)
+	min := uint8(15)
+	for row, idx := range h {
+		if v := s.get(row, idx); v < min {
+			min = v
+		}
+	}
+	return min
+}
+
+// halve right-shifts every counter by 1, aging the whole sketch so long-dormant keys stop
+// outscoring genuinely hot ones
+func (s *countMinSketch) halve() {
+	for row := range s.rows {
+		for i, b := range s.rows[row] {
+			s.rows[row][i] = ((b & 0x0f) >> 1) | (((b >> 4) >> 1) << 4)
+		}
+	}
+}
+
+// doorkeeper - a small bloom filter flagging keys seen at least once in the current sample
+// window, so a brand-new key isn't penalized for reading 0 out of the sketch
+type doorkeeper struct {
+	bits []byte
+	mask uint32
+}
+
+func createDoorkeeper(counters uint32) *doorkeeper {
+	width := pow2Ceil(counters)
+	return &doorkeeper{bits: make([]byte, (width+7)/8), mask: width - 1}
+}
+
+// test reports whether k's bits are already set, without setting them
+func (d *doorkeeper) test(k string) bool {
+	h := tinyLFUHashes(k, d.mask)
+	return d.bits[h[0]>>3]&(1<<(h[0]&7)) != 0 && d.bits[h[1]>>3]&(1<<(h[1]&7)) != 0
+}
+
+func (d *doorkeeper) set(k string) {
+	h := tinyLFUHashes(k, d.mask)
+	d.bits[h[0]>>3] |= 1 << (h[0] & 7)
+	d.bits[h[1]>>3] |= 1 << (h[1] & 7)
+}
+
+func (d *doorkeeper) reset() {
+	for i := range d.bits {
+		d.bits[i] = 0
+	}
+}
+
+// tinyLFUFilter - per-shard TinyLFU admission filter: a count-min sketch frequency estimator
+// backed by a doorkeeper bloom filter, following the TinyLFU technique (Einziger, Friedman &
+// Manes, 2017); gates which newcomers are allowed to evict the current LRU tail
+type tinyLFUFilter struct {
+	cms        *countMinSketch
+	door       *doorkeeper
+	sampleSize uint32 // touches before the sketch/doorkeeper are aged
+	samples    uint32
+}
+
+func createTinyLFUFilter(counters uint32) *tinyLFUFilter {
+	if counters < 1 {
+		counters = 1
+	}
+	width := counters * 10
+	return &tinyLFUFilter{
+		cms:        createCountMinSketch(width),
+		door:       createDoorkeeper(width),
+		sampleSize: width,
+	}
+}
+
+// touch records an access to k, aging the filter once every sampleSize touches
+func (f *tinyLFUFilter) touch(k string) {
+	f.door.set(k)
+	f.cms.add(k)
+	if f.samples++; f.samples >= f.sampleSize {
+		f.samples = 0
+		f.cms.halve()
+		f.door.reset()
+	}
+}
+
+// admit decides whether candidate should be allowed to evict victim: strictly more estimated
+// frequency wins, except a victim that looks stone cold (frequency 0) yields to any candidate
+// that hasn't been seen in this sample window yet, so genuinely new keys still get a foothold
+func (f *tinyLFUFilter) admit(candidate, victim string) bool {
+	vf := f.cms.estimate(victim)
+	if vf == 0 && !f.door.test(candidate) {
+		return true
+	}
+	return f.cms.estimate(candidate) > vf
+}
@@ -0,0 +1,86 @@
+package ecache
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+// zipfHitRatio drives n accesses drawn from a Zipfian distribution (a small number of keys
+// dominate, the same shape as most production caches) through get, and returns the fraction that
+// hit.
+func zipfHitRatio(get func(key string) bool, z *rand.Zipf, n int) float64 {
+	hits := 0
+	for i := 0; i < n; i++ {
+		key := strconv.FormatUint(z.Uint64(), 10)
+		if get(key) {
+			hits++
+		}
+	}
+	return float64(hits) / float64(n)
+}
+
+// TestTinyLFURejectsColdCandidate drives a direct admit/reject decision through the public API: a
+// candidate touched only once (low frequency estimate, but already past its first touch so the
+// doorkeeper's "brand new" exemption no longer applies) must not be allowed to evict a victim that
+// has been accessed far more often, and Inspect's REJECT callback must fire to say so.
+func TestTinyLFURejectsColdCandidate(t *testing.T) {
+	c := NewLRUCache(0, 2).WithTinyLFU(2) // bucketCnt=0 resolves to a single shard
+
+	var rejects int
+	c.Inspect(func(action int, key string, _ *Value, _ int) {
+		if action == REJECT {
+			rejects++
+		}
+	})
+
+	c.Put("hot1", 1)
+	c.Put("hot2", 2)
+	for i := 0; i < 20; i++ { // drive both victims' frequency estimates well above a single touch
+		c.Get("hot1")
+		c.Get("hot2")
+	}
+	c.Get("cold") // one touch: doorkeeper bit set, frequency estimate far below either hot key's
+
+	c.Put("cold", 99)
+
+	if rejects != 1 {
+		t.Fatalf("REJECT callback fired %d times, want 1", rejects)
+	}
+	if _, ok := c.Get("cold"); ok {
+		t.Fatal("cold should have been refused admission, not cached")
+	}
+}
+
+// BenchmarkLRUZipfHitRatio and BenchmarkTinyLFUZipfHitRatio replay the same Zipfian-distributed
+// key sequence, over a working set two orders of magnitude larger than the cache, through a plain
+// LRU cache and a TinyLFU-gated one, reporting the hit ratio each achieves so the two are directly
+// comparable: TinyLFU should win because the admission filter refuses to let a burst of one-off
+// keys evict the genuinely hot working set.
+func BenchmarkLRUZipfHitRatio(b *testing.B) {
+	c := NewLRUCache(1, 100)
+	z := rand.NewZipf(rand.New(rand.NewSource(1)), 1.5, 1, 10000)
+	b.ResetTimer()
+	ratio := zipfHitRatio(func(key string) bool {
+		if _, ok := c.Get(key); ok {
+			return true
+		}
+		c.Put(key, key)
+		return false
+	}, z, b.N)
+	b.ReportMetric(ratio, "hit_ratio")
+}
+
+func BenchmarkTinyLFUZipfHitRatio(b *testing.B) {
+	c := NewLRUCache(1, 100).WithTinyLFU(100)
+	z := rand.NewZipf(rand.New(rand.NewSource(1)), 1.5, 1, 10000)
+	b.ResetTimer()
+	ratio := zipfHitRatio(func(key string) bool {
+		if _, ok := c.Get(key); ok {
+			return true
+		}
+		c.Put(key, key)
+		return false
+	}, z, b.N)
+	b.ReportMetric(ratio, "hit_ratio")
+}